@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/robbiet480/bearcatter/wavparse"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd() *cobra.Command {
+	var format, input, output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export decoded recording locations to a GPX or KML track file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(format, input, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "gpx", `track format to write, "gpx" or "kml"`)
+	cmd.Flags().StringVar(&input, "input", "", "directory of recordings to walk")
+	cmd.Flags().StringVar(&output, "output", "", "file to write the track to")
+	cmd.MarkFlagRequired("input")
+	cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func runExport(format, input, output string) error {
+	entries, err := decodeRecordingsDir(input)
+	if err != nil {
+		return err
+	}
+
+	out, createErr := os.Create(output)
+	if createErr != nil {
+		return createErr
+	}
+	defer out.Close()
+
+	switch strings.ToLower(format) {
+	case "gpx":
+		return wavparse.ExportGPX(entries, out)
+	case "kml":
+		return wavparse.ExportKML(entries, out)
+	default:
+		return fmt.Errorf("unknown export format %q, want \"gpx\" or \"kml\"", format)
+	}
+}
+
+// decodeRecordingsDir decodes every .wav file under root across a worker
+// pool, returning once all of them have been decoded.
+func decodeRecordingsDir(root string) ([]*wavparse.Recording, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results, err := wavparse.DecodeDir(ctx, root, wavparse.DecodeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*wavparse.Recording
+	for result := range results {
+		if result.Err != nil {
+			cancel()
+			// Drain the rest so DecodeDir's worker goroutines, which are
+			// blocked sending on results, can observe ctx.Done and exit.
+			for range results {
+			}
+			return nil, fmt.Errorf("decoding %s: %w", result.Path, result.Err)
+		}
+		entries = append(entries, result.Recording)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robbiet480/bearcatter/wavparse"
+	"github.com/robbiet480/bearcatter/wavparse/index"
+	"github.com/spf13/cobra"
+)
+
+func newIndexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "index",
+		Short: "Build and query a SQLite index of decoded recordings",
+	}
+
+	cmd.AddCommand(newIndexBuildCmd())
+	cmd.AddCommand(newIndexSearchCmd())
+
+	return cmd
+}
+
+func newIndexBuildCmd() *cobra.Command {
+	var dbPath string
+
+	cmd := &cobra.Command{
+		Use:   "build DIR",
+		Short: "Decode every recording under DIR into the index",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIndexBuild(args[0], dbPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, "db", "bearcatter.db", "path to the SQLite index database")
+
+	return cmd
+}
+
+func runIndexBuild(dir, dbPath string) error {
+	idx, err := index.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results, err := wavparse.DecodeDir(ctx, dir, wavparse.DecodeOptions{
+		Progress: func(done, total int) {
+			fmt.Printf("\rbearcatter: decoding %d/%d", done, total)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var indexed int
+	for result := range results {
+		if result.Err != nil {
+			cancel()
+			// Drain the rest so DecodeDir's worker goroutines, which are
+			// blocked sending on results, can observe ctx.Done and exit.
+			for range results {
+			}
+			return fmt.Errorf("decoding %s: %w", result.Path, result.Err)
+		}
+		if err := idx.Add(result.Recording); err != nil {
+			cancel()
+			for range results {
+			}
+			return fmt.Errorf("indexing %s: %w", result.Recording.File, err)
+		}
+		indexed++
+	}
+	fmt.Println()
+
+	fmt.Printf("bearcatter: indexed %d recordings into %s\n", indexed, dbPath)
+	return nil
+}
+
+func newIndexSearchCmd() *cobra.Command {
+	var dbPath, since, until, freq, bbox, text string
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search the recording index",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIndexSearch(dbPath, since, until, freq, bbox, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&dbPath, "db", "bearcatter.db", "path to the SQLite index database")
+	cmd.Flags().StringVar(&since, "since", "", "only return recordings at or after this RFC3339 timestamp")
+	cmd.Flags().StringVar(&until, "until", "", "only return recordings at or before this RFC3339 timestamp")
+	cmd.Flags().StringVar(&freq, "freq", "", `frequency range in MHz, e.g. "154.000-155.000"`)
+	cmd.Flags().StringVar(&bbox, "bbox", "", "bounding box as minLat,minLon,maxLat,maxLon")
+	cmd.Flags().StringVar(&text, "text", "", "full-text query over channel/department/system/site/UnitIDName/TGID")
+
+	return cmd
+}
+
+func runIndexSearch(dbPath, since, until, freq, bbox, text string) error {
+	idx, err := index.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+
+	query := index.Query{Text: text}
+
+	if since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return fmt.Errorf("parsing --since: %w", err)
+		}
+		query.Since = t
+	}
+	if until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return fmt.Errorf("parsing --until: %w", err)
+		}
+		query.Until = t
+	}
+	if freq != "" {
+		min, max, err := parseFreqRange(freq)
+		if err != nil {
+			return fmt.Errorf("parsing --freq: %w", err)
+		}
+		query.MinFrequency, query.MaxFrequency = min, max
+	}
+	if bbox != "" {
+		b, err := parseBBox(bbox)
+		if err != nil {
+			return fmt.Errorf("parsing --bbox: %w", err)
+		}
+		query.BBox = b
+	}
+
+	results, err := idx.Search(query)
+	if err != nil {
+		return err
+	}
+
+	for _, recording := range results {
+		fmt.Fprintln(os.Stdout, recording.File)
+	}
+
+	return nil
+}
+
+func parseFreqRange(s string) (min, max float64, err error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf(`want "MIN-MAX", got %q`, s)
+	}
+
+	min, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return min, max, nil
+}
+
+func parseBBox(s string) (*index.BBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf(`want "minLat,minLon,maxLat,maxLon", got %q`, s)
+	}
+
+	values := make([]float64, 4)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return &index.BBox{MinLat: values[0], MinLon: values[1], MaxLat: values[2], MaxLon: values[3]}, nil
+}
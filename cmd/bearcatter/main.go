@@ -0,0 +1,30 @@
+// Command bearcatter provides CLI tooling for working with decoded Uniden
+// scanner recordings on top of the wavparse package.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "bearcatter",
+		Short: "Tools for working with Uniden scanner recordings",
+	}
+
+	root.AddCommand(newExportCmd())
+	root.AddCommand(newServeCmd())
+	root.AddCommand(newIndexCmd())
+
+	return root
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/robbiet480/bearcatter/server"
+	"github.com/spf13/cobra"
+)
+
+func newServeCmd() *cobra.Command {
+	var dir, addr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve decoded recordings over HTTP with live SSE updates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(dir, addr)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "directory of recordings to watch")
+	cmd.Flags().StringVar(&addr, "addr", ":8080", "address to listen on")
+	cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+func runServe(dir, addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(dir)
+
+	go func() {
+		if err := srv.Run(ctx); err != nil && err != context.Canceled {
+			fmt.Fprintf(os.Stderr, "bearcatter: watcher stopped: %v\n", err)
+		}
+	}()
+
+	httpServer := &http.Server{Addr: addr, Handler: srv.Handler()}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("bearcatter: serving recordings from %s on %s\n", dir, addr)
+
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
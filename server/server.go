@@ -0,0 +1,325 @@
+// Package server exposes decoded Uniden scanner recordings over HTTP,
+// watching a directory for newly-closed WAV files and streaming them to
+// subscribers over Server-Sent Events.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/robbiet480/bearcatter/wavparse"
+)
+
+// quietPeriod is how long a .wav file must go without a write event before
+// it's considered closed and safe to decode.
+const quietPeriod = 2 * time.Second
+
+// Event is emitted over the /events SSE stream whenever a new recording is
+// decoded.
+type Event struct {
+	Type      string              `json:"type"`
+	Recording *wavparse.Recording `json:"recording"`
+}
+
+// Server serves decoded recordings from Dir and watches it for new ones.
+type Server struct {
+	Dir string
+
+	mu         sync.RWMutex
+	recordings map[string]*wavparse.Recording
+
+	subMu       sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// New returns a Server that will index recordings under dir.
+func New(dir string) *Server {
+	return &Server{
+		Dir:         dir,
+		recordings:  make(map[string]*wavparse.Recording),
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Handler returns the HTTP handler serving /recordings, /recordings/{id}/audio,
+// and /events.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/recordings", s.handleRecordings)
+	mux.HandleFunc("/recordings/", s.handleRecordingAudio)
+	mux.HandleFunc("/events", s.handleEvents)
+	return mux
+}
+
+// Run performs an initial scan of Dir, then watches it for new .wav files
+// until ctx is cancelled, decoding each one once it's stopped being written
+// to and broadcasting it to SSE subscribers.
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.scan(ctx); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(s.Dir); err != nil {
+		return err
+	}
+
+	pending := map[string]*time.Timer{}
+	var pendingMu sync.Mutex
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !strings.EqualFold(filepath.Ext(event.Name), ".wav") {
+				continue
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+				continue
+			}
+
+			path := event.Name
+
+			pendingMu.Lock()
+			if timer, exists := pending[path]; exists {
+				timer.Reset(quietPeriod)
+			} else {
+				pending[path] = time.AfterFunc(quietPeriod, func() {
+					pendingMu.Lock()
+					delete(pending, path)
+					pendingMu.Unlock()
+					s.ingestAndNotify(path)
+				})
+			}
+			pendingMu.Unlock()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("server: watcher error: %v", err)
+		}
+	}
+}
+
+// scan decodes every existing .wav file under s.Dir across a worker pool,
+// so an initial backlog of thousands of recordings doesn't get loaded into
+// memory or decoded one at a time.
+func (s *Server) scan(ctx context.Context) error {
+	results, err := wavparse.DecodeDir(ctx, s.Dir, wavparse.DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	for result := range results {
+		if result.Err != nil {
+			log.Printf("server: decoding %s: %v", result.Path, result.Err)
+			continue
+		}
+		s.store(result.Recording)
+	}
+
+	return nil
+}
+
+func (s *Server) store(recording *wavparse.Recording) {
+	s.mu.Lock()
+	s.recordings[recording.File] = recording
+	s.mu.Unlock()
+}
+
+func (s *Server) ingest(path string) (*wavparse.Recording, error) {
+	recording, err := wavparse.DecodeRecording(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(recording)
+
+	return recording, nil
+}
+
+func (s *Server) ingestAndNotify(path string) {
+	recording, err := s.ingest(path)
+	if err != nil {
+		log.Printf("server: decoding %s: %v", path, err)
+		return
+	}
+	s.broadcast(Event{Type: "recording", Recording: recording})
+}
+
+func (s *Server) subscribe() chan Event {
+	ch := make(chan Event, 16)
+	s.subMu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.subMu.Unlock()
+	return ch
+}
+
+func (s *Server) unsubscribe(ch chan Event) {
+	s.subMu.Lock()
+	delete(s.subscribers, ch)
+	s.subMu.Unlock()
+	close(ch)
+}
+
+func (s *Server) broadcast(evt Event) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- evt:
+		default:
+			log.Printf("server: dropping event for slow subscriber")
+		}
+	}
+}
+
+type recordingsResponse struct {
+	Recordings []*wavparse.Recording `json:"recordings"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"pageSize"`
+	Total      int                   `json:"total"`
+}
+
+func (s *Server) handleRecordings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	page, pageSize := paginationParams(r)
+
+	s.mu.RLock()
+	all := make([]*wavparse.Recording, 0, len(s.recordings))
+	for _, recording := range s.recordings {
+		all = append(all, recording)
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].File < all[j].File
+	})
+
+	start := (page - 1) * pageSize
+	if start > len(all) {
+		start = len(all)
+	}
+	end := start + pageSize
+	if end > len(all) {
+		end = len(all)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recordingsResponse{
+		Recordings: all[start:end],
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      len(all),
+	})
+}
+
+func paginationParams(r *http.Request) (page, pageSize int) {
+	page, pageSize = 1, 50
+
+	if v := r.URL.Query().Get("page"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			page = n
+		}
+	}
+	if v := r.URL.Query().Get("pageSize"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			pageSize = n
+		}
+	}
+
+	return page, pageSize
+}
+
+func (s *Server) handleRecordingAudio(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/recordings/")
+	id := strings.TrimSuffix(rest, "/audio")
+	if id == rest || id == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.RLock()
+	recording, found := s.recordings[id]
+	s.mu.RUnlock()
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(filepath.Join(s.Dir, recording.File))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.ServeContent(w, r, recording.File, info.ModTime(), f)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			payload, err := json.Marshal(evt)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
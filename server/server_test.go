@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robbiet480/bearcatter/wavparse"
+)
+
+// syncRecorder wraps an httptest.ResponseRecorder so its Body can be
+// written from a handler goroutine and read from a test goroutine without
+// racing, and signals wrote after every Write so callers can wait for data
+// instead of polling the buffer.
+type syncRecorder struct {
+	mu    sync.Mutex
+	rec   *httptest.ResponseRecorder
+	wrote chan struct{}
+}
+
+func newSyncRecorder() *syncRecorder {
+	return &syncRecorder{rec: httptest.NewRecorder(), wrote: make(chan struct{}, 1)}
+}
+
+func (s *syncRecorder) Header() http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rec.Header()
+}
+
+func (s *syncRecorder) WriteHeader(code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rec.WriteHeader(code)
+}
+
+func (s *syncRecorder) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	n, err := s.rec.Write(b)
+	s.mu.Unlock()
+
+	select {
+	case s.wrote <- struct{}{}:
+	default:
+	}
+
+	return n, err
+}
+
+func (s *syncRecorder) Flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rec.Flush()
+}
+
+func (s *syncRecorder) body() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rec.Body.String()
+}
+
+func waitForSubscribers(t *testing.T, s *Server, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.subMu.Lock()
+		count := len(s.subscribers)
+		s.subMu.Unlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d subscriber(s)", n)
+}
+
+func TestHandleRecordingsPagination(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := New(t.TempDir())
+	for i := 0; i < 5; i++ {
+		srv.store(&wavparse.Recording{File: fmt.Sprintf("%02d.wav", i)})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/recordings?page=2&pageSize=2", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	assert.Equal(http.StatusOK, rec.Code)
+
+	var resp struct {
+		Recordings []*wavparse.Recording `json:"recordings"`
+		Page       int                   `json:"page"`
+		PageSize   int                   `json:"pageSize"`
+		Total      int                   `json:"total"`
+	}
+	assert.NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(2, resp.Page)
+	assert.Equal(2, resp.PageSize)
+	assert.Equal(5, resp.Total)
+	if assert.Len(resp.Recordings, 2) {
+		assert.Equal("02.wav", resp.Recordings[0].File)
+		assert.Equal("03.wav", resp.Recordings[1].File)
+	}
+}
+
+func TestHandleRecordingsPaginationOutOfRange(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := New(t.TempDir())
+	srv.store(&wavparse.Recording{File: "a.wav"})
+
+	req := httptest.NewRequest(http.MethodGet, "/recordings?page=5&pageSize=10", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	assert.Equal(http.StatusOK, rec.Code)
+
+	var resp struct {
+		Recordings []*wavparse.Recording `json:"recordings"`
+	}
+	assert.NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Empty(resp.Recordings, "an out-of-range page should return an empty slice, not an error")
+}
+
+func TestHandleRecordingAudioNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := New(t.TempDir())
+
+	req := httptest.NewRequest(http.MethodGet, "/recordings/missing.wav/audio", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	assert.Equal(http.StatusNotFound, rec.Code)
+}
+
+func TestHandleRecordingAudioServesRangeRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	content := []byte("0123456789")
+	assert.NoError(os.WriteFile(filepath.Join(dir, "a.wav"), content, 0o644))
+
+	srv := New(dir)
+	srv.store(&wavparse.Recording{File: "a.wav"})
+
+	req := httptest.NewRequest(http.MethodGet, "/recordings/a.wav/audio", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	assert.Equal(http.StatusPartialContent, rec.Code)
+	assert.Equal("2345", rec.Body.String())
+}
+
+func TestHandleEventsStreamsBroadcastEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := New(t.TempDir())
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	ctx, cancel := context.WithCancel(req.Context())
+	req = req.WithContext(ctx)
+	rec := newSyncRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		srv.Handler().ServeHTTP(rec, req)
+		close(done)
+	}()
+
+	waitForSubscribers(t, srv, 1)
+
+	srv.broadcast(Event{Type: "recording", Recording: &wavparse.Recording{File: "a.wav"}})
+
+	select {
+	case <-rec.wrote:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the broadcast event to be written")
+	}
+
+	cancel()
+	<-done
+
+	body := rec.body()
+	assert.Contains(body, `"type":"recording"`)
+	assert.Contains(body, `"File":"a.wav"`)
+}
+
+func TestBroadcastDropsForSlowSubscriber(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := New(t.TempDir())
+	ch := srv.subscribe()
+	defer srv.unsubscribe(ch)
+
+	const bufferSize = 16
+	for i := 0; i < bufferSize+4; i++ {
+		srv.broadcast(Event{Type: fmt.Sprintf("evt-%d", i)})
+	}
+
+	assert.Len(ch, bufferSize, "the subscriber channel buffer should be full, not blocked on or grown past")
+
+	for i := 0; i < bufferSize; i++ {
+		evt := <-ch
+		assert.Equal(fmt.Sprintf("evt-%d", i), evt.Type, "already-buffered events should be delivered in order; only the overflow is dropped")
+	}
+}
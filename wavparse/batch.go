@@ -0,0 +1,127 @@
+package wavparse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// DecodeResult is the outcome of decoding a single file within a DecodeDir
+// call.
+type DecodeResult struct {
+	Path      string
+	Recording *Recording
+	Err       error
+}
+
+// DecodeOptions configures a DecodeDir call.
+type DecodeOptions struct {
+	// Workers is the number of files decoded concurrently. Zero or
+	// negative means runtime.NumCPU().
+	Workers int
+
+	// Progress, if set, is called after every file is decoded with the
+	// number of files completed so far and the total number discovered.
+	// It may be called concurrently from multiple goroutines.
+	Progress func(done, total int)
+}
+
+// DecodeDir walks root for .wav files and decodes them concurrently across
+// a worker pool, streaming a DecodeResult per file on the returned channel.
+// The channel is closed once every file has been decoded or ctx is
+// cancelled. DecodeDir itself returns as soon as the walk and worker pool
+// have been started; callers should range over the channel to drain
+// results.
+func DecodeDir(ctx context.Context, root string, opts DecodeOptions) (<-chan DecodeResult, error) {
+	paths, err := walkWavFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	if workers == 0 {
+		results := make(chan DecodeResult)
+		close(results)
+		return results, nil
+	}
+
+	jobs := make(chan string)
+	results := make(chan DecodeResult)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	var done int
+	var mu sync.Mutex
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				recording, decodeErr := DecodeRecording(path)
+
+				select {
+				case results <- DecodeResult{Path: path, Recording: recording, Err: decodeErr}:
+				case <-ctx.Done():
+					return
+				}
+
+				if opts.Progress != nil {
+					mu.Lock()
+					done++
+					opts.Progress(done, len(paths))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, path := range paths {
+			select {
+			case jobs <- path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results, nil
+}
+
+// walkWavFiles returns every .wav file under root, in the order filepath.Walk
+// visits them.
+func walkWavFiles(root string) ([]string, error) {
+	var paths []string
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".wav") {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
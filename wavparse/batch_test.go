@@ -0,0 +1,86 @@
+package wavparse
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeMinimalWAVs(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		writeMinimalWAV(t, filepath.Join(dir, name))
+	}
+}
+
+func TestDecodeDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeMinimalWAVs(t, dir, "a.wav", "b.wav", "c.wav")
+
+	var mu sync.Mutex
+	var progress [][2]int
+
+	results, err := DecodeDir(context.Background(), dir, DecodeOptions{
+		Workers: 2,
+		Progress: func(done, total int) {
+			mu.Lock()
+			progress = append(progress, [2]int{done, total})
+			mu.Unlock()
+		},
+	})
+	assert.NoError(err)
+
+	seen := map[string]bool{}
+	for result := range results {
+		assert.NoError(result.Err)
+		if assert.NotNil(result.Recording) {
+			seen[result.Recording.File] = true
+		}
+	}
+
+	assert.Len(seen, 3)
+	assert.True(seen["a.wav"] && seen["b.wav"] && seen["c.wav"])
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(progress, 3, "Progress should be called once per file")
+	assert.Equal([2]int{3, 3}, progress[len(progress)-1], "the final progress call should report completion")
+}
+
+func TestDecodeDirCancellation(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeMinimalWAVs(t, dir, "a.wav", "b.wav", "c.wav")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := DecodeDir(ctx, dir, DecodeOptions{Workers: 1})
+	assert.NoError(err)
+
+	for range results {
+		// Drain whatever raced in before cancellation was observed; the
+		// channel must still close on its own.
+	}
+}
+
+func TestDecodeDirEmptyDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+
+	results, err := DecodeDir(context.Background(), dir, DecodeOptions{})
+	assert.NoError(err)
+
+	count := 0
+	for range results {
+		count++
+	}
+	assert.Equal(0, count)
+}
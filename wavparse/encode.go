@@ -0,0 +1,189 @@
+package wavparse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// riffChunk is one top-level RIFF chunk: a 4-byte ID, a little-endian
+// 32-bit size, and that many bytes of data (plus a pad byte if the size is
+// odd, which readRIFFChunks/writeRIFFChunks handle transparently).
+type riffChunk struct {
+	ID   string
+	Data []byte
+}
+
+// EncodeRecording writes dst as a RIFF/WAVE file built from src, copying
+// every chunk of src verbatim (including the "data" chunk holding the
+// audio) except the Uniden private chunk and the public INFO chunk, which
+// are rewritten from r. This lets a caller fix a mislabeled TGID, backfill
+// a location recorded separately, or scrub a UnitID without touching the
+// audio itself.
+func EncodeRecording(dst io.WriteSeeker, src io.Reader, r Recording) error {
+	chunks, err := readRIFFChunks(src)
+	if err != nil {
+		return err
+	}
+
+	kept := chunks[:0]
+	for _, c := range chunks {
+		if c.ID == "LIST" || c.ID == "unid" {
+			continue
+		}
+		kept = append(kept, c)
+	}
+
+	kept = append(kept, buildInfoChunk(r), buildPrivateChunk(r))
+
+	return writeRIFFChunks(dst, kept)
+}
+
+func readRIFFChunks(src io.Reader) ([]riffChunk, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(src, header[:]); err != nil {
+		return nil, fmt.Errorf("wavparse: reading RIFF header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("wavparse: not a RIFF/WAVE file")
+	}
+
+	var chunks []riffChunk
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(src, chunkHeader[:]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, fmt.Errorf("wavparse: reading chunk header: %w", err)
+		}
+
+		id := string(chunkHeader[0:4])
+		size := binary.LittleEndian.Uint32(chunkHeader[4:8])
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(src, data); err != nil {
+			return nil, fmt.Errorf("wavparse: reading %s chunk body: %w", id, err)
+		}
+		if size%2 == 1 {
+			if _, err := io.CopyN(io.Discard, src, 1); err != nil && err != io.EOF {
+				return nil, fmt.Errorf("wavparse: reading %s chunk pad byte: %w", id, err)
+			}
+		}
+
+		chunks = append(chunks, riffChunk{ID: id, Data: data})
+	}
+
+	return chunks, nil
+}
+
+func writeRIFFChunks(dst io.WriteSeeker, chunks []riffChunk) error {
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+
+	for _, c := range chunks {
+		if len(c.ID) != 4 {
+			return fmt.Errorf("wavparse: chunk id %q must be 4 bytes", c.ID)
+		}
+
+		body.WriteString(c.ID)
+
+		var size [4]byte
+		binary.LittleEndian.PutUint32(size[:], uint32(len(c.Data)))
+		body.Write(size[:])
+
+		body.Write(c.Data)
+		if len(c.Data)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+
+	if _, err := dst.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if _, err := dst.Write([]byte("RIFF")); err != nil {
+		return err
+	}
+
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(body.Len()))
+	if _, err := dst.Write(size[:]); err != nil {
+		return err
+	}
+
+	_, err := dst.Write(body.Bytes())
+	return err
+}
+
+// buildInfoChunk rewrites the public "LIST"/"INFO" chunk from r's exported
+// fields, mirroring what DecodeRecording reads into Recording.Public.
+func buildInfoChunk(r Recording) riffChunk {
+	var body bytes.Buffer
+	body.WriteString("INFO")
+
+	writeInfoField(&body, "IPRD", r.Public.Product)
+	if r.Public.Timestamp != nil {
+		writeInfoField(&body, "ICRD", r.Public.Timestamp.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	writeInfoField(&body, "INAM", r.Public.Channel)
+	writeInfoField(&body, "IART", r.Public.System)
+	writeInfoField(&body, "ISBJ", r.Public.Department)
+	writeInfoField(&body, "ICMT", r.Public.FavoriteListName)
+	writeInfoField(&body, "IKEY", r.Public.TGIDFreq)
+	writeInfoField(&body, "ICOP", strconv.FormatInt(r.Public.UnitID, 10))
+
+	return riffChunk{ID: "LIST", Data: body.Bytes()}
+}
+
+func writeInfoField(buf *bytes.Buffer, id, value string) {
+	data := []byte(value)
+
+	buf.WriteString(id)
+
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(data)))
+	buf.Write(size[:])
+
+	buf.Write(data)
+	if len(data)%2 == 1 {
+		buf.WriteByte(0)
+	}
+}
+
+// buildPrivateChunk rewrites the Uniden "unid" private chunk from r's
+// exported fields, mirroring what DecodeRecording reads into
+// Recording.Private. Each field is a 4-byte tag, a little-endian 16-bit
+// length, and that many bytes of UTF-8 text.
+func buildPrivateChunk(r Recording) riffChunk {
+	var body bytes.Buffer
+
+	writePrivateField(&body, "SYTY", r.Private.System.Type)
+	writePrivateField(&body, "SYNM", r.Private.System.Name)
+	writePrivateField(&body, "FREQ", strconv.FormatFloat(r.Private.Frequency, 'f', -1, 64))
+	writePrivateField(&body, "FAVN", r.Private.FavoriteList.Name)
+	writePrivateField(&body, "DEPT", r.Private.Department)
+	writePrivateField(&body, "CHAN", r.Private.Channel)
+	writePrivateField(&body, "SITE", r.Private.Site.Name)
+	writePrivateField(&body, "TGID", r.Private.TGID)
+	writePrivateField(&body, "UNID", strconv.FormatInt(r.Private.UnitID, 10))
+	writePrivateField(&body, "UIDN", r.Private.UnitIDName)
+	writePrivateField(&body, "LAT0", strconv.FormatFloat(r.Private.Location.Latitude, 'f', -1, 64))
+	writePrivateField(&body, "LON0", strconv.FormatFloat(r.Private.Location.Longitude, 'f', -1, 64))
+
+	return riffChunk{ID: "unid", Data: body.Bytes()}
+}
+
+func writePrivateField(buf *bytes.Buffer, tag, value string) {
+	data := []byte(value)
+
+	buf.WriteString(tag)
+
+	var size [2]byte
+	binary.LittleEndian.PutUint16(size[:], uint16(len(data)))
+	buf.Write(size[:])
+
+	buf.Write(data)
+}
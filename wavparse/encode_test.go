@@ -0,0 +1,124 @@
+package wavparse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeMinimalWAV writes a tiny valid RIFF/WAVE file (PCM "fmt " + silent
+// "data", no metadata chunks) to path, suitable as EncodeRecording's src.
+func writeMinimalWAV(t *testing.T, path string) {
+	t.Helper()
+
+	const (
+		channels      = 1
+		sampleRate    = 8000
+		bitsPerSample = 16
+	)
+
+	var fmtChunk bytes.Buffer
+	binary.Write(&fmtChunk, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&fmtChunk, binary.LittleEndian, uint16(channels))
+	binary.Write(&fmtChunk, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(&fmtChunk, binary.LittleEndian, uint32(sampleRate*channels*bitsPerSample/8))
+	binary.Write(&fmtChunk, binary.LittleEndian, uint16(channels*bitsPerSample/8))
+	binary.Write(&fmtChunk, binary.LittleEndian, uint16(bitsPerSample))
+
+	data := make([]byte, 1600) // 100ms of silence at the rate above
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("creating synthetic wav: %v", err)
+	}
+	defer f.Close()
+
+	if err := writeRIFFChunks(f, []riffChunk{
+		{ID: "fmt ", Data: fmtChunk.Bytes()},
+		{ID: "data", Data: data},
+	}); err != nil {
+		t.Fatalf("writing synthetic wav: %v", err)
+	}
+}
+
+func TestEncodeRecordingRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	srcPath := filepath.Join(t.TempDir(), "synthetic.wav")
+	writeMinimalWAV(t, srcPath)
+
+	original, decodeErr := DecodeRecording(srcPath)
+	if decodeErr != nil {
+		t.Fatalf("error decoding synthetic fixture: %v", decodeErr)
+	}
+
+	ts := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	mutated := *original
+	mutated.Public.Product = "BCD536HP"
+	mutated.Public.Timestamp = &ts
+	mutated.Public.Channel = "Dispatch"
+	mutated.Public.System = "Metro County"
+	mutated.Public.Department = "Police"
+	mutated.Public.FavoriteListName = "Metro PD"
+	mutated.Public.TGIDFreq = "1001"
+	mutated.Public.UnitID = 4001001
+	mutated.Private.System.Type = "TRS"
+	mutated.Private.System.Name = "Metro County"
+	mutated.Private.Frequency = 154.43
+	mutated.Private.FavoriteList.Name = "Metro PD"
+	mutated.Private.Department = "Police"
+	mutated.Private.Channel = "Dispatch"
+	mutated.Private.Site.Name = "Main"
+	mutated.Private.TGID = "1001"
+	mutated.Private.UnitID = 4001001
+	mutated.Private.Location.Latitude = 39.1
+	mutated.Private.Location.Longitude = -84.5
+
+	src, openErr := os.Open(srcPath)
+	if openErr != nil {
+		t.Fatalf("error opening synthetic fixture: %v", openErr)
+	}
+	defer src.Close()
+
+	dstPath := filepath.Join(t.TempDir(), "encoded.wav")
+	dst, createErr := os.Create(dstPath)
+	if createErr != nil {
+		t.Fatalf("error creating encode destination: %v", createErr)
+	}
+	defer dst.Close()
+
+	if encodeErr := EncodeRecording(dst, src, mutated); encodeErr != nil {
+		t.Fatalf("error encoding recording: %v", encodeErr)
+	}
+
+	reDecoded, reDecodeErr := DecodeRecording(dstPath)
+	if reDecodeErr != nil {
+		t.Fatalf("error re-decoding encoded file: %v", reDecodeErr)
+	}
+
+	assert.Equal(mutated.Duration, reDecoded.Duration, "Duration should be equal")
+	assert.Equal(mutated.Public.Product, reDecoded.Public.Product, "Products (public) should be equal")
+	assert.Equal(mutated.Public.Timestamp.UTC(), reDecoded.Public.Timestamp.UTC(), "Timestamps (public) should be equal")
+	assert.Equal(mutated.Public.System, reDecoded.Public.System, "System Names (public) should be equal")
+	assert.Equal(mutated.Public.Department, reDecoded.Public.Department, "Department Names (public) should be equal")
+	assert.Equal(mutated.Public.Channel, reDecoded.Public.Channel, "Channel Names (public) should be equal")
+	assert.Equal(mutated.Public.FavoriteListName, reDecoded.Public.FavoriteListName, "Favorite List Names (public) should be equal")
+	assert.Equal(mutated.Public.TGIDFreq, reDecoded.Public.TGIDFreq, "TGID (public) should be equal")
+	assert.Equal(mutated.Public.UnitID, reDecoded.Public.UnitID, "UnitID (public) should be equal")
+	assert.Equal(mutated.Private.System.Type, reDecoded.Private.System.Type, "System types (private) should be equal")
+	assert.Equal(mutated.Private.Frequency, reDecoded.Private.Frequency, "Frequencies (private) should be equal")
+	assert.Equal(mutated.Private.Department, reDecoded.Private.Department, "Department Names (private) should be equal")
+	assert.Equal(mutated.Private.Channel, reDecoded.Private.Channel, "Channel Names (private) should be equal")
+	assert.Equal(mutated.Private.FavoriteList.Name, reDecoded.Private.FavoriteList.Name, "Favorite List Names (private) should be equal")
+	assert.Equal(mutated.Private.Site.Name, reDecoded.Private.Site.Name, "Site Names (private) should be equal")
+	assert.Equal(mutated.Private.TGID, reDecoded.Private.TGID, "TGID (private) should be equal")
+	assert.Equal(mutated.Private.UnitID, reDecoded.Private.UnitID, "UnitID (private) should be equal")
+	assert.Equal(mutated.Private.Location.Latitude, reDecoded.Private.Location.Latitude, "Latitude (private) should be equal")
+	assert.Equal(mutated.Private.Location.Longitude, reDecoded.Private.Location.Longitude, "Longitude (private) should be equal")
+}
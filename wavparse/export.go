@@ -0,0 +1,209 @@
+package wavparse
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// gpxDocument is the minimal GPX 1.1 structure needed to hold a single track
+// built from a set of recordings.
+type gpxDocument struct {
+	XMLName xml.Name `xml:"gpx"`
+	Version string   `xml:"version,attr"`
+	Creator string   `xml:"creator,attr"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Trk     gpxTrk   `xml:"trk"`
+}
+
+type gpxTrk struct {
+	Name   string    `xml:"name"`
+	Trkseg gpxTrkseg `xml:"trkseg"`
+}
+
+type gpxTrkseg struct {
+	Trkpts []gpxTrkpt `xml:"trkpt"`
+}
+
+type gpxTrkpt struct {
+	Lat        float64       `xml:"lat,attr"`
+	Lon        float64       `xml:"lon,attr"`
+	Time       string        `xml:"time,omitempty"`
+	Extensions gpxExtensions `xml:"extensions"`
+}
+
+type gpxExtensions struct {
+	Frequency  float64 `xml:"frequency"`
+	TGID       string  `xml:"tgid"`
+	System     string  `xml:"system"`
+	Department string  `xml:"department"`
+	Channel    string  `xml:"channel"`
+	UnitID     int64   `xml:"unitid"`
+}
+
+// kmlDocument is the minimal KML 2.2 structure needed to hold a single
+// placemark per recording.
+type kmlDocument struct {
+	XMLName  xml.Name       `xml:"kml"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Document kmlDocumentTag `xml:"Document"`
+}
+
+type kmlDocumentTag struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name         string          `xml:"name"`
+	TimeStamp    *kmlTimeStamp   `xml:"TimeStamp,omitempty"`
+	Point        kmlPoint        `xml:"Point"`
+	ExtendedData kmlExtendedData `xml:"ExtendedData"`
+}
+
+type kmlTimeStamp struct {
+	When string `xml:"when"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlExtendedData struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// locatedRecordings returns entries with a non-zero Private.Location,
+// stably sorted by Public.Timestamp, ready to be emitted as a track.
+func locatedRecordings(entries []*Recording) []*Recording {
+	located := make([]*Recording, 0, len(entries))
+	for _, r := range entries {
+		if r.Private.Location.Latitude == 0 && r.Private.Location.Longitude == 0 {
+			continue
+		}
+		located = append(located, r)
+	}
+
+	sort.SliceStable(located, func(i, j int) bool {
+		return timestampOf(located[i]).Before(timestampOf(located[j]))
+	})
+
+	return located
+}
+
+func timestampOf(r *Recording) time.Time {
+	if r.Public.Timestamp == nil {
+		return time.Time{}
+	}
+	return *r.Public.Timestamp
+}
+
+// ExportGPX writes entries as a single GPX 1.1 track, one <trkpt> per
+// recording whose Private.Location is set. Points carry frequency, TGID,
+// system/department/channel names, and UnitID as track point extensions so
+// the track can be opened in Google Earth, gpsbabel, or OsmAnd.
+func ExportGPX(entries []*Recording, w io.Writer) error {
+	located := locatedRecordings(entries)
+
+	doc := gpxDocument{
+		Version: "1.1",
+		Creator: "bearcatter",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Trk: gpxTrk{
+			Name: "Bearcatter Export",
+		},
+	}
+
+	for _, r := range located {
+		trkpt := gpxTrkpt{
+			Lat: r.Private.Location.Latitude,
+			Lon: r.Private.Location.Longitude,
+			Extensions: gpxExtensions{
+				Frequency:  r.Private.Frequency,
+				TGID:       r.Private.TGID,
+				System:     r.Private.System.Name,
+				Department: r.Private.Department,
+				Channel:    r.Private.Channel,
+				UnitID:     r.Private.UnitID,
+			},
+		}
+		if r.Public.Timestamp != nil {
+			trkpt.Time = r.Public.Timestamp.UTC().Format(time.RFC3339)
+		}
+		doc.Trk.Trkseg.Trkpts = append(doc.Trk.Trkseg.Trkpts, trkpt)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+// ExportKML writes entries as a single KML 2.2 document, one <Placemark>
+// per recording whose Private.Location is set. Frequency, TGID,
+// system/department/channel names, and UnitID are carried as
+// <ExtendedData> so the track can be opened in Google Earth, gpsbabel, or
+// OsmAnd.
+func ExportKML(entries []*Recording, w io.Writer) error {
+	located := locatedRecordings(entries)
+
+	doc := kmlDocument{
+		Xmlns: "http://www.opengis.net/kml/2.2",
+		Document: kmlDocumentTag{
+			Name: "Bearcatter Export",
+		},
+	}
+
+	for _, r := range located {
+		placemark := kmlPlacemark{
+			Name: r.Public.Channel,
+			Point: kmlPoint{
+				Coordinates: formatKMLCoordinates(r.Private.Location.Longitude, r.Private.Location.Latitude),
+			},
+			ExtendedData: kmlExtendedData{
+				Data: []kmlData{
+					{Name: "frequency", Value: formatFloat(r.Private.Frequency)},
+					{Name: "tgid", Value: r.Private.TGID},
+					{Name: "system", Value: r.Private.System.Name},
+					{Name: "department", Value: r.Private.Department},
+					{Name: "channel", Value: r.Private.Channel},
+					{Name: "unitid", Value: formatInt(r.Private.UnitID)},
+				},
+			},
+		}
+		if r.Public.Timestamp != nil {
+			placemark.TimeStamp = &kmlTimeStamp{When: r.Public.Timestamp.UTC().Format(time.RFC3339)}
+		}
+		doc.Document.Placemarks = append(doc.Document.Placemarks, placemark)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+func formatKMLCoordinates(lon, lat float64) string {
+	return formatFloat(lon) + "," + formatFloat(lat) + ",0"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatInt(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
@@ -0,0 +1,88 @@
+package wavparse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocatedRecordingsFiltersAndSortsStably(t *testing.T) {
+	assert := assert.New(t)
+
+	t1 := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	t2 := time.Date(2020, time.January, 2, 3, 5, 0, 0, time.UTC)
+
+	noFix := &Recording{Public: PublicMetadata{Channel: "No Fix", Timestamp: &t1}}
+
+	first := &Recording{Public: PublicMetadata{Channel: "First", Timestamp: &t1}}
+	first.Private.Location = LocationInfo{Latitude: 39.1, Longitude: -84.5}
+
+	secondSameTime := &Recording{Public: PublicMetadata{Channel: "Second Same Time", Timestamp: &t1}}
+	secondSameTime.Private.Location = LocationInfo{Latitude: 39.2, Longitude: -84.6}
+
+	later := &Recording{Public: PublicMetadata{Channel: "Later", Timestamp: &t2}}
+	later.Private.Location = LocationInfo{Latitude: 39.3, Longitude: -84.7}
+
+	located := locatedRecordings([]*Recording{noFix, first, secondSameTime, later})
+
+	if assert.Len(located, 3) {
+		assert.Equal("First", located[0].Public.Channel, "equal timestamps must preserve input order")
+		assert.Equal("Second Same Time", located[1].Public.Channel, "equal timestamps must preserve input order")
+		assert.Equal("Later", located[2].Public.Channel)
+	}
+}
+
+func TestExportGPX(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	r := &Recording{Public: PublicMetadata{Channel: "Dispatch", Timestamp: &ts}}
+	r.Private.Frequency = 154.43
+	r.Private.TGID = "1001"
+	r.Private.System.Name = "Metro County"
+	r.Private.Department = "Police"
+	r.Private.Channel = "Dispatch"
+	r.Private.UnitID = 4001001
+	r.Private.Location = LocationInfo{Latitude: 39.1, Longitude: -84.5}
+
+	noFix := &Recording{}
+
+	var buf bytes.Buffer
+	assert.NoError(ExportGPX([]*Recording{noFix, r}, &buf))
+
+	out := buf.String()
+	assert.Contains(out, `<gpx version="1.1" creator="bearcatter" xmlns="http://www.topografix.com/GPX/1/1">`)
+	assert.Contains(out, `<trkpt lat="39.1" lon="-84.5">`)
+	assert.Contains(out, `<time>2020-01-02T03:04:05Z</time>`)
+	assert.Contains(out, "<frequency>154.43</frequency>")
+	assert.Contains(out, "<tgid>1001</tgid>")
+	assert.Contains(out, "<unitid>4001001</unitid>")
+	assert.Equal(1, strings.Count(out, "<trkpt"), "a recording with no fix must be filtered out")
+}
+
+func TestExportKML(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	r := &Recording{Public: PublicMetadata{Channel: "Dispatch", Timestamp: &ts}}
+	r.Private.Frequency = 154.43
+	r.Private.TGID = "1001"
+	r.Private.Location = LocationInfo{Latitude: 39.1, Longitude: -84.5}
+
+	noFix := &Recording{}
+
+	var buf bytes.Buffer
+	assert.NoError(ExportKML([]*Recording{noFix, r}, &buf))
+
+	out := buf.String()
+	assert.Contains(out, `<kml xmlns="http://www.opengis.net/kml/2.2">`)
+	assert.Contains(out, `<name>Dispatch</name>`)
+	assert.Contains(out, `<coordinates>-84.5,39.1,0</coordinates>`)
+	assert.Contains(out, `<when>2020-01-02T03:04:05Z</when>`)
+	assert.Contains(out, `<Data name="frequency">`)
+	assert.Contains(out, "<value>154.43</value>")
+	assert.Equal(1, strings.Count(out, "<Placemark>"), "a recording with no fix must be filtered out")
+}
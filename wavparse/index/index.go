@@ -0,0 +1,198 @@
+// Package index provides a SQLite/FTS5-backed store of decoded recordings
+// so months of scanner audio can be queried by time range, frequency
+// range, location, or full text without re-parsing every WAV.
+package index
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/robbiet480/bearcatter/wavparse"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS recordings (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	file      TEXT NOT NULL UNIQUE,
+	timestamp INTEGER,
+	frequency REAL,
+	latitude  REAL,
+	longitude REAL,
+	data      BLOB NOT NULL
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS recordings_fts USING fts5(
+	channel, department, system, site, unit_id_name, tgid,
+	content='',
+	tokenize='porter'
+);
+`
+
+// Index is a SQLite-backed store of decoded recordings.
+type Index struct {
+	db *sql.DB
+}
+
+// Open creates or opens a SQLite database at path and ensures its schema
+// exists.
+func Open(path string) (*Index, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("index: applying schema: %w", err)
+	}
+
+	return &Index{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (idx *Index) Close() error {
+	return idx.db.Close()
+}
+
+// Add indexes r, replacing any existing entry for the same file.
+func (idx *Index) Add(r *wavparse.Recording) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("index: marshaling %s: %w", r.File, err)
+	}
+
+	var timestamp sql.NullInt64
+	if r.Public.Timestamp != nil {
+		timestamp = sql.NullInt64{Int64: r.Public.Timestamp.Unix(), Valid: true}
+	}
+
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM recordings_fts WHERE rowid = (SELECT id FROM recordings WHERE file = ?)`, r.File); err != nil {
+		return fmt.Errorf("index: clearing stale fts row for %s: %w", r.File, err)
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO recordings (file, timestamp, frequency, latitude, longitude, data)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(file) DO UPDATE SET
+			timestamp = excluded.timestamp,
+			frequency = excluded.frequency,
+			latitude = excluded.latitude,
+			longitude = excluded.longitude,
+			data = excluded.data
+	`, r.File, timestamp, r.Private.Frequency, r.Private.Location.Latitude, r.Private.Location.Longitude, data)
+	if err != nil {
+		return fmt.Errorf("index: inserting %s: %w", r.File, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		if err := tx.QueryRow(`SELECT id FROM recordings WHERE file = ?`, r.File).Scan(&id); err != nil {
+			return fmt.Errorf("index: looking up id for %s: %w", r.File, err)
+		}
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO recordings_fts (rowid, channel, department, system, site, unit_id_name, tgid)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, r.Public.Channel, r.Public.Department, r.Public.System, r.Private.Site.Name, r.Private.UnitIDName, r.Private.TGID); err != nil {
+		return fmt.Errorf("index: indexing fts row for %s: %w", r.File, err)
+	}
+
+	return tx.Commit()
+}
+
+// BBox is an inclusive latitude/longitude bounding box.
+type BBox struct {
+	MinLat float64
+	MinLon float64
+	MaxLat float64
+	MaxLon float64
+}
+
+// Query filters recordings returned by Search. The zero value of each
+// field disables that filter, except BBox, which is only applied when
+// non-nil.
+type Query struct {
+	Since        time.Time
+	Until        time.Time
+	MinFrequency float64
+	MaxFrequency float64
+	BBox         *BBox
+	Text         string
+}
+
+// Search returns recordings matching q, ordered by Public.Timestamp
+// ascending.
+func (idx *Index) Search(q Query) ([]*wavparse.Recording, error) {
+	sqlQuery := "SELECT recordings.data FROM recordings"
+
+	var where []string
+	var args []interface{}
+
+	if q.Text != "" {
+		sqlQuery += " JOIN recordings_fts ON recordings_fts.rowid = recordings.id"
+		where = append(where, "recordings_fts MATCH ?")
+		args = append(args, q.Text)
+	}
+	if !q.Since.IsZero() {
+		where = append(where, "recordings.timestamp >= ?")
+		args = append(args, q.Since.Unix())
+	}
+	if !q.Until.IsZero() {
+		where = append(where, "recordings.timestamp <= ?")
+		args = append(args, q.Until.Unix())
+	}
+	if q.MinFrequency != 0 {
+		where = append(where, "recordings.frequency >= ?")
+		args = append(args, q.MinFrequency)
+	}
+	if q.MaxFrequency != 0 {
+		where = append(where, "recordings.frequency <= ?")
+		args = append(args, q.MaxFrequency)
+	}
+	if q.BBox != nil {
+		where = append(where, "recordings.latitude BETWEEN ? AND ?", "recordings.longitude BETWEEN ? AND ?")
+		args = append(args, q.BBox.MinLat, q.BBox.MaxLat, q.BBox.MinLon, q.BBox.MaxLon)
+	}
+
+	if len(where) > 0 {
+		sqlQuery += " WHERE " + strings.Join(where, " AND ")
+	}
+	sqlQuery += " ORDER BY recordings.timestamp ASC"
+
+	rows, err := idx.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("index: searching: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*wavparse.Recording
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var recording wavparse.Recording
+		if err := json.Unmarshal(data, &recording); err != nil {
+			return nil, err
+		}
+		results = append(results, &recording)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,60 @@
+package index
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robbiet480/bearcatter/wavparse"
+)
+
+func newTestRecording(file, channel string, ts time.Time, freq, lat, lon float64) *wavparse.Recording {
+	r := &wavparse.Recording{File: file}
+	r.Public.Timestamp = &ts
+	r.Public.Channel = channel
+	r.Private.Channel = channel
+	r.Private.Frequency = freq
+	r.Private.Location.Latitude = lat
+	r.Private.Location.Longitude = lon
+	return r
+}
+
+func TestIndexAddAndSearch(t *testing.T) {
+	assert := assert.New(t)
+
+	dbPath := filepath.Join(t.TempDir(), "bearcatter.db")
+	idx, err := Open(dbPath)
+	assert.NoError(err)
+	defer idx.Close()
+
+	base := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.NoError(idx.Add(newTestRecording("a.wav", "Dispatch", base, 154.43, 39.1, -84.5)))
+	assert.NoError(idx.Add(newTestRecording("b.wav", "Fireground", base.Add(time.Hour), 154.28, 40.0, -85.0)))
+
+	results, err := idx.Search(Query{Text: "Dispatch"})
+	assert.NoError(err)
+	if assert.Len(results, 1) {
+		assert.Equal("a.wav", results[0].File)
+	}
+
+	results, err = idx.Search(Query{MinFrequency: 154.3})
+	assert.NoError(err)
+	if assert.Len(results, 1) {
+		assert.Equal("a.wav", results[0].File)
+	}
+
+	results, err = idx.Search(Query{BBox: &BBox{MinLat: 39.5, MinLon: -86, MaxLat: 41, MaxLon: -84.8}})
+	assert.NoError(err)
+	if assert.Len(results, 1) {
+		assert.Equal("b.wav", results[0].File)
+	}
+
+	results, err = idx.Search(Query{Since: base.Add(30 * time.Minute)})
+	assert.NoError(err)
+	if assert.Len(results, 1) {
+		assert.Equal("b.wav", results[0].File)
+	}
+}
@@ -0,0 +1,67 @@
+package sidecar
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+
+	"github.com/gocarina/gocsv"
+)
+
+type arcXTRow struct {
+	SystemName     string  `csv:"System Name"`
+	SiteName       string  `csv:"Site Name"`
+	DepartmentName string  `csv:"Group Name"`
+	ChannelName    string  `csv:"Alpha Tag"`
+	ScanMode       string  `csv:"Service Type"`
+	Frequency      float64 `csv:"Frequency"`
+	TGID           string  `csv:"ID"`
+}
+
+// ARCXTSource loads sidecar metadata from a favorites-list CSV exported by
+// ARC-XT or Sentinel HP. Favorites-list rows have no per-recording file
+// association, so Entry.FileName is left blank; callers merging these
+// entries (e.g. DecodeRecordingWithSidecar) match them by frequency and
+// TGID instead.
+type ARCXTSource struct{}
+
+// Load reads path as an ARC-XT/Sentinel favorites-list CSV export and
+// returns its rows as Entry values.
+func (ARCXTSource) Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gocsvMu.Lock()
+	defer gocsvMu.Unlock()
+
+	gocsv.SetCSVReader(func(in io.Reader) gocsv.CSVReader {
+		return csv.NewReader(in)
+	})
+
+	var rows []*arcXTRow
+	if err := gocsv.UnmarshalFile(f, &rows); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(rows))
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		entries = append(entries, Entry{
+			SystemName:     row.SystemName,
+			SiteName:       row.SiteName,
+			DepartmentName: row.DepartmentName,
+			ChannelName:    row.ChannelName,
+			ScanMode:       row.ScanMode,
+			Frequency:      row.Frequency,
+			TGID:           row.TGID,
+			FavoriteName:   row.ChannelName,
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,44 @@
+package sidecar
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// JSONLinesSource loads sidecar metadata from a generic file with one JSON
+// object per line, each matching the Entry field names.
+type JSONLinesSource struct{}
+
+// Load reads path as newline-delimited JSON and returns each line as an
+// Entry. Blank lines are skipped.
+func (JSONLinesSource) Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("sidecar: parsing %s line %d: %w", path, lineNum, err)
+		}
+
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
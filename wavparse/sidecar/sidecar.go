@@ -0,0 +1,47 @@
+// Package sidecar loads scanner-recording metadata exported by tools other
+// than the scanner itself, so it can be merged onto WAV files whose own
+// LIST/INFO chunks are missing fields.
+package sidecar
+
+import (
+	"sync"
+	"time"
+)
+
+// gocsvMu serializes access to gocsv's process-global CSV reader
+// configuration (gocsv.SetCSVReader). WavPlayerSource and ARCXTSource both
+// need different delimiters, so each Load must hold this lock for the
+// entire set-then-unmarshal sequence to avoid racing with a concurrent
+// Load of the other format.
+var gocsvMu sync.Mutex
+
+// Entry is the neutral superset of metadata fields any Source
+// implementation can populate, regardless of which tool produced the
+// export.
+type Entry struct {
+	FileName       string        `json:"fileName"`
+	Product        string        `json:"product,omitempty"`
+	DateAndTime    time.Time     `json:"dateAndTime,omitempty"`
+	Duration       time.Duration `json:"duration,omitempty"`
+	ScanMode       string        `json:"scanMode,omitempty"`
+	SystemType     string        `json:"systemType,omitempty"`
+	Frequency      float64       `json:"frequency,omitempty"`
+	Code           string        `json:"code,omitempty"`
+	FavoriteName   string        `json:"favoriteName,omitempty"`
+	SystemName     string        `json:"systemName,omitempty"`
+	DepartmentName string        `json:"departmentName,omitempty"`
+	ChannelName    string        `json:"channelName,omitempty"`
+	SiteName       string        `json:"siteName,omitempty"`
+	TGID           string        `json:"tgid,omitempty"`
+	UnitID         int64         `json:"unitID,omitempty"`
+	UnitIDName     string        `json:"unitIDName,omitempty"`
+	Latitude       float64       `json:"latitude,omitempty"`
+	Longitude      float64       `json:"longitude,omitempty"`
+}
+
+// Source loads sidecar metadata entries from a file produced by a
+// scanner-adjacent tool, such as Uniden WavPlayer, ARC-XT/Sentinel, or a
+// hand-written index.
+type Source interface {
+	Load(path string) ([]Entry, error)
+}
@@ -0,0 +1,79 @@
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWavPlayerSourceLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	path := writeTempFile(t, "wavplayer.csv", "File path;File name;Scanner type;Date and time;Duration;Scan mode;Type;Frequency;Code;Favorite name;System name;Department name;Channel name;Site;TGID;UID;UID Name;Latitude;Longitude\n"+
+		"C:\\recordings;001_20200102_030405.wav;BCD536HP;1/02/2020 03:04:05 AM;00:01:30;Favorites;TRS;154.430000;;Metro PD;Metro County;Police;Dispatch;Main;1001;4001001;Car 12;39.1000;-84.5000\n")
+
+	entries, err := WavPlayerSource{}.Load(path)
+	assert.NoError(err)
+	assert.Len(entries, 1)
+
+	entry := entries[0]
+	assert.Equal("001_20200102_030405.wav", entry.FileName)
+	assert.Equal("BCD536HP", entry.Product)
+	assert.Equal("Metro County", entry.SystemName)
+	assert.Equal("Police", entry.DepartmentName)
+	assert.Equal("Dispatch", entry.ChannelName)
+	assert.Equal("1001", entry.TGID)
+	assert.Equal(int64(4001001), entry.UnitID)
+	assert.Equal(39.1000, entry.Latitude)
+	assert.Equal(-84.5000, entry.Longitude)
+	assert.Equal(90*time.Second, entry.Duration)
+}
+
+func TestARCXTSourceLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	path := writeTempFile(t, "arcxt.csv", "System Name,Site Name,Group Name,Alpha Tag,Service Type,Frequency,ID\n"+
+		"Metro County,Main,Police,Dispatch,TRS,154.430000,1001\n")
+
+	entries, err := ARCXTSource{}.Load(path)
+	assert.NoError(err)
+	assert.Len(entries, 1)
+
+	entry := entries[0]
+	assert.Equal("Metro County", entry.SystemName)
+	assert.Equal("Main", entry.SiteName)
+	assert.Equal("Police", entry.DepartmentName)
+	assert.Equal("Dispatch", entry.ChannelName)
+	assert.Equal("1001", entry.TGID)
+	assert.Equal(154.430000, entry.Frequency)
+}
+
+func TestJSONLinesSourceLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	path := writeTempFile(t, "sidecar.jsonl", `{"fileName":"001_20200102_030405.wav","systemName":"Metro County","tgid":"1001","latitude":39.1,"longitude":-84.5}`+"\n\n")
+
+	entries, err := JSONLinesSource{}.Load(path)
+	assert.NoError(err)
+	assert.Len(entries, 1)
+
+	entry := entries[0]
+	assert.Equal("001_20200102_030405.wav", entry.FileName)
+	assert.Equal("Metro County", entry.SystemName)
+	assert.Equal("1001", entry.TGID)
+	assert.Equal(39.1, entry.Latitude)
+	assert.Equal(-84.5, entry.Longitude)
+}
+
+func writeTempFile(t *testing.T, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing temp fixture: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,123 @@
+package sidecar
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+)
+
+type wavPlayerTime struct{ time.Time }
+
+const wavPlayerTimeFormat = "1/02/2006 03:04:05 PM"
+
+// MarshalCSV converts the internal date as a CSV string.
+func (date *wavPlayerTime) MarshalCSV() (string, error) {
+	return date.Time.Format(wavPlayerTimeFormat), nil
+}
+
+// UnmarshalCSV converts the CSV string as an internal date.
+func (date *wavPlayerTime) UnmarshalCSV(csv string) (err error) {
+	date.Time, err = time.Parse(wavPlayerTimeFormat, csv)
+	return err
+}
+
+type wavPlayerDuration struct{ time.Duration }
+
+// MarshalCSV converts the internal duration as a CSV string.
+func (clock *wavPlayerDuration) MarshalCSV() (string, error) {
+	return clock.Duration.String(), nil
+}
+
+// UnmarshalCSV converts the CSV string as an internal duration.
+func (clock *wavPlayerDuration) UnmarshalCSV(csv string) (err error) {
+	split := strings.Split(csv, ":")
+	if len(split) != 3 {
+		return fmt.Errorf("sidecar: invalid WavPlayer duration %q", csv)
+	}
+	clock.Duration, err = time.ParseDuration(fmt.Sprintf("%sh%sm%ss", split[0], split[1], split[2]))
+	return err
+}
+
+type wavPlayerRow struct {
+	FilePath       string            `csv:"File path"`
+	FileName       string            `csv:"File name"`
+	Product        string            `csv:"Scanner type"`
+	DateAndTime    wavPlayerTime     `csv:"Date and time"`
+	Duration       wavPlayerDuration `csv:"Duration"`
+	ScanMode       string            `csv:"Scan mode"`
+	SystemType     string            `csv:"Type"`
+	Frequency      float64           `csv:"Frequency"`
+	Code           string            `csv:"Code"`
+	FavoriteName   string            `csv:"Favorite name"`
+	SystemName     string            `csv:"System name"`
+	DepartmentName string            `csv:"Department name"`
+	ChannelName    string            `csv:"Channel name"`
+	SiteName       string            `csv:"Site"`
+	TGID           string            `csv:"TGID"`
+	UnitID         int64             `csv:"UID"`
+	UnitIDName     string            `csv:"UID Name"`
+	Latitude       float64           `csv:"Latitude"`
+	Longitude      float64           `csv:"Longitude"`
+}
+
+// WavPlayerSource loads sidecar metadata from the semicolon-delimited CSV
+// exported by Uniden WavPlayer.
+type WavPlayerSource struct{}
+
+// Load reads path as a WavPlayer CSV export and returns its rows as Entry
+// values.
+func (WavPlayerSource) Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gocsvMu.Lock()
+	defer gocsvMu.Unlock()
+
+	gocsv.SetCSVReader(func(in io.Reader) gocsv.CSVReader {
+		r := csv.NewReader(in)
+		r.Comma = ';'
+		return r
+	})
+
+	var rows []*wavPlayerRow
+	if err := gocsv.UnmarshalFile(f, &rows); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(rows))
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		entries = append(entries, Entry{
+			FileName:       row.FileName,
+			Product:        row.Product,
+			DateAndTime:    row.DateAndTime.Time,
+			Duration:       row.Duration.Duration,
+			ScanMode:       row.ScanMode,
+			SystemType:     row.SystemType,
+			Frequency:      row.Frequency,
+			Code:           row.Code,
+			FavoriteName:   row.FavoriteName,
+			SystemName:     row.SystemName,
+			DepartmentName: row.DepartmentName,
+			ChannelName:    row.ChannelName,
+			SiteName:       row.SiteName,
+			TGID:           row.TGID,
+			UnitID:         row.UnitID,
+			UnitIDName:     row.UnitIDName,
+			Latitude:       row.Latitude,
+			Longitude:      row.Longitude,
+		})
+	}
+
+	return entries, nil
+}
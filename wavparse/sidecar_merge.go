@@ -0,0 +1,130 @@
+package wavparse
+
+import (
+	"path/filepath"
+
+	"github.com/robbiet480/bearcatter/wavparse/sidecar"
+)
+
+// DecodeRecordingWithSidecar decodes path like DecodeRecording, then merges
+// in any fields from the matching sidecar.Entry that the WAV's own
+// LIST/INFO chunks left blank. This covers older BC75XLT firmware, which
+// omits fields such as site name and UnitIDName from the private chunk.
+//
+// Entries are matched by file name where the source provides one (Uniden
+// WavPlayer, JSONLinesSource). ARC-XT/Sentinel favorites-list exports carry
+// no per-recording file association, so those entries are matched by
+// frequency and TGID instead, read off the WAV's own decoded Private chunk.
+func DecodeRecordingWithSidecar(path string, source sidecar.Source, sidecarPath string) (*Recording, error) {
+	recording, err := DecodeRecording(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := source.Load(sidecarPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry := findSidecarEntry(entries, filepath.Base(path), recording); entry != nil {
+		mergeSidecarEntry(recording, *entry)
+	}
+
+	return recording, nil
+}
+
+func findSidecarEntry(entries []sidecar.Entry, fileName string, recording *Recording) *sidecar.Entry {
+	for i := range entries {
+		if entries[i].FileName != "" && entries[i].FileName == fileName {
+			return &entries[i]
+		}
+	}
+
+	// Favorites-list entries with no FileName (ARC-XT/Sentinel) can only be
+	// matched by the frequency+TGID pair a favorites list actually encodes.
+	if recording.Private.Frequency == 0 || recording.Private.TGID == "" {
+		return nil
+	}
+	for i := range entries {
+		if entries[i].FileName != "" {
+			continue
+		}
+		if entries[i].Frequency == recording.Private.Frequency && entries[i].TGID == recording.Private.TGID {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// mergeSidecarEntry fills in any field on r that the RIFF decode left at its
+// zero value using the corresponding field from entry. Fields the WAV
+// already populated are left untouched. Public and private mirrors of the
+// same value (e.g. Public.Channel and Private.Channel) are filled in
+// independently, since older firmware can leave either chunk incomplete
+// without the other.
+func mergeSidecarEntry(r *Recording, entry sidecar.Entry) {
+	if r.Public.Product == "" {
+		r.Public.Product = entry.Product
+	}
+	if r.Public.Timestamp == nil && !entry.DateAndTime.IsZero() {
+		t := entry.DateAndTime
+		r.Public.Timestamp = &t
+	}
+	if r.Duration == 0 {
+		r.Duration = entry.Duration
+	}
+	if r.Private.System.Type == "" {
+		r.Private.System.Type = entry.SystemType
+	}
+	if r.Private.Frequency == 0 {
+		r.Private.Frequency = entry.Frequency
+	}
+	if r.Public.FavoriteListName == "" {
+		r.Public.FavoriteListName = entry.FavoriteName
+	}
+	if r.Private.FavoriteList.Name == "" {
+		r.Private.FavoriteList.Name = entry.FavoriteName
+	}
+	if r.Public.System == "" {
+		r.Public.System = entry.SystemName
+	}
+	if r.Private.System.Name == "" {
+		r.Private.System.Name = entry.SystemName
+	}
+	if r.Public.Department == "" {
+		r.Public.Department = entry.DepartmentName
+	}
+	if r.Private.Department == "" {
+		r.Private.Department = entry.DepartmentName
+	}
+	if r.Public.Channel == "" {
+		r.Public.Channel = entry.ChannelName
+	}
+	if r.Private.Channel == "" {
+		r.Private.Channel = entry.ChannelName
+	}
+	if r.Private.Site.Name == "" {
+		r.Private.Site.Name = entry.SiteName
+	}
+	if r.Private.UnitIDName == "" {
+		r.Private.UnitIDName = entry.UnitIDName
+	}
+	if r.Public.TGIDFreq == "" {
+		r.Public.TGIDFreq = entry.TGID
+	}
+	if r.Private.TGID == "" {
+		r.Private.TGID = entry.TGID
+	}
+	if r.Public.UnitID == 0 {
+		r.Public.UnitID = entry.UnitID
+	}
+	if r.Private.UnitID == 0 {
+		r.Private.UnitID = entry.UnitID
+	}
+	if r.Private.Location.Latitude == 0 {
+		r.Private.Location.Latitude = entry.Latitude
+	}
+	if r.Private.Location.Longitude == 0 {
+		r.Private.Location.Longitude = entry.Longitude
+	}
+}
@@ -0,0 +1,131 @@
+package wavparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robbiet480/bearcatter/wavparse/sidecar"
+)
+
+func TestFindSidecarEntry(t *testing.T) {
+	assert := assert.New(t)
+
+	entries := []sidecar.Entry{
+		{FileName: "a.wav"},
+		{FileName: "b.wav"},
+	}
+
+	found := findSidecarEntry(entries, "b.wav", &Recording{})
+	if assert.NotNil(found) {
+		assert.Equal("b.wav", found.FileName)
+	}
+
+	assert.Nil(findSidecarEntry(entries, "c.wav", &Recording{}))
+}
+
+func TestFindSidecarEntryByFrequencyAndTGID(t *testing.T) {
+	assert := assert.New(t)
+
+	entries := []sidecar.Entry{
+		{FileName: "", Frequency: 154.43, TGID: "1001", SystemName: "Metro County"},
+		{FileName: "", Frequency: 155.00, TGID: "1002", SystemName: "Other County"},
+	}
+
+	r := &Recording{}
+	r.Private.Frequency = 154.43
+	r.Private.TGID = "1001"
+
+	found := findSidecarEntry(entries, "no-such-file.wav", r)
+	if assert.NotNil(found) {
+		assert.Equal("Metro County", found.SystemName)
+	}
+
+	r.Private.TGID = "9999"
+	assert.Nil(findSidecarEntry(entries, "no-such-file.wav", r), "a frequency match alone must not be enough")
+}
+
+func TestMergeSidecarEntryFillsOnlyZeroFields(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &Recording{}
+	r.Public.Channel = "Already Set"
+	r.Private.Frequency = 154.43
+
+	entry := sidecar.Entry{
+		Product:        "BCD536HP",
+		SystemName:     "Metro County",
+		DepartmentName: "Police",
+		ChannelName:    "Dispatch",
+		SiteName:       "Main",
+		UnitIDName:     "Car 12",
+		TGID:           "1001",
+		UnitID:         4001001,
+		Frequency:      999,
+		Latitude:       39.1,
+		Longitude:      -84.5,
+	}
+
+	mergeSidecarEntry(r, entry)
+
+	assert.Equal("Already Set", r.Public.Channel, "a populated public field must not be overwritten")
+	assert.Equal(154.43, r.Private.Frequency, "a populated private field must not be overwritten")
+
+	assert.Equal("Dispatch", r.Private.Channel, "a blank private mirror should be filled even though its public twin was already set")
+	assert.Equal("BCD536HP", r.Public.Product)
+	assert.Equal("Metro County", r.Public.System)
+	assert.Equal("Metro County", r.Private.System.Name)
+	assert.Equal("Police", r.Public.Department)
+	assert.Equal("Police", r.Private.Department)
+	assert.Equal("Main", r.Private.Site.Name)
+	assert.Equal("Car 12", r.Private.UnitIDName)
+	assert.Equal("1001", r.Public.TGIDFreq)
+	assert.Equal("1001", r.Private.TGID)
+	assert.Equal(int64(4001001), r.Public.UnitID)
+	assert.Equal(int64(4001001), r.Private.UnitID)
+	assert.Equal(39.1, r.Private.Location.Latitude)
+	assert.Equal(-84.5, r.Private.Location.Longitude)
+}
+
+func TestDecodeRecordingWithSidecar(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	wavPath := filepath.Join(dir, "001_20200102.wav")
+	writeMinimalWAV(t, wavPath)
+
+	sidecarPath := filepath.Join(dir, "sidecar.jsonl")
+	line := `{"fileName":"001_20200102.wav","systemName":"Metro County","channelName":"Dispatch","tgid":"1001","latitude":39.1,"longitude":-84.5}` + "\n"
+	if err := os.WriteFile(sidecarPath, []byte(line), 0o644); err != nil {
+		t.Fatalf("writing sidecar fixture: %v", err)
+	}
+
+	recording, err := DecodeRecordingWithSidecar(wavPath, sidecar.JSONLinesSource{}, sidecarPath)
+	assert.NoError(err)
+
+	assert.Equal("Metro County", recording.Public.System)
+	assert.Equal("Dispatch", recording.Public.Channel)
+	assert.Equal("1001", recording.Public.TGIDFreq)
+	assert.Equal(39.1, recording.Private.Location.Latitude)
+	assert.Equal(-84.5, recording.Private.Location.Longitude)
+}
+
+func TestDecodeRecordingWithSidecarNoMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	wavPath := filepath.Join(dir, "001_20200102.wav")
+	writeMinimalWAV(t, wavPath)
+
+	sidecarPath := filepath.Join(dir, "sidecar.jsonl")
+	line := `{"fileName":"someone-else.wav","systemName":"Metro County"}` + "\n"
+	if err := os.WriteFile(sidecarPath, []byte(line), 0o644); err != nil {
+		t.Fatalf("writing sidecar fixture: %v", err)
+	}
+
+	recording, err := DecodeRecordingWithSidecar(wavPath, sidecar.JSONLinesSource{}, sidecarPath)
+	assert.NoError(err)
+	assert.Equal("", recording.Public.System, "a sidecar entry for a different file must not be merged in")
+}
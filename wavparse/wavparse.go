@@ -0,0 +1,235 @@
+package wavparse
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Recording is the decoded result of a single Uniden scanner WAV file,
+// combining the audio's intrinsic properties with the public (RIFF
+// "LIST"/"INFO") and private (Uniden "unid") metadata chunks.
+type Recording struct {
+	File     string
+	Duration time.Duration
+	Public   PublicMetadata
+	Private  PrivateMetadata
+}
+
+// PublicMetadata is what a generic media player can show a user: it comes
+// from the standard RIFF "LIST"/"INFO" chunk.
+type PublicMetadata struct {
+	Product          string
+	Timestamp        *time.Time
+	FavoriteListName string
+	System           string
+	Department       string
+	Channel          string
+	TGIDFreq         string
+	UnitID           int64
+}
+
+// PrivateMetadata is Uniden-specific detail from the proprietary "unid"
+// chunk that a generic media player has no use for.
+type PrivateMetadata struct {
+	System       SystemInfo
+	Frequency    float64
+	FavoriteList FavoriteListInfo
+	Department   string
+	Channel      string
+	Site         SiteInfo
+	TGID         string
+	UnitID       int64
+	UnitIDName   string
+	Location     LocationInfo
+}
+
+// SystemInfo identifies the trunked or conventional radio system a
+// recording was captured from.
+type SystemInfo struct {
+	Type string
+	Name string
+}
+
+// FavoriteListInfo identifies the scanner favorites list active when a
+// recording was captured.
+type FavoriteListInfo struct {
+	Name string
+}
+
+// SiteInfo identifies the trunked radio site a recording was captured from.
+type SiteInfo struct {
+	Name string
+}
+
+// LocationInfo is the scanner's GPS fix at the time a recording was
+// captured. A zero value means no fix was available.
+type LocationInfo struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// DecodeRecording parses path as a Uniden scanner WAV recording, reading
+// its audio duration from the "fmt "/"data" chunks and its metadata from
+// the public "LIST"/"INFO" chunk and the private "unid" chunk.
+func DecodeRecording(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	chunks, err := readRIFFChunks(f)
+	if err != nil {
+		return nil, fmt.Errorf("wavparse: decoding %s: %w", path, err)
+	}
+
+	r := &Recording{File: filepath.Base(path)}
+
+	var fmtChunk, dataChunk *riffChunk
+	for i := range chunks {
+		switch chunks[i].ID {
+		case "fmt ":
+			fmtChunk = &chunks[i]
+		case "data":
+			dataChunk = &chunks[i]
+		case "LIST":
+			if err := parseInfoChunk(chunks[i].Data, r); err != nil {
+				return nil, fmt.Errorf("wavparse: decoding %s: %w", path, err)
+			}
+		case "unid":
+			if err := parsePrivateChunk(chunks[i].Data, r); err != nil {
+				return nil, fmt.Errorf("wavparse: decoding %s: %w", path, err)
+			}
+		}
+	}
+
+	if fmtChunk != nil && dataChunk != nil {
+		duration, err := audioDuration(fmtChunk.Data, dataChunk.Data)
+		if err != nil {
+			return nil, fmt.Errorf("wavparse: decoding %s: %w", path, err)
+		}
+		r.Duration = duration
+	}
+
+	return r, nil
+}
+
+// audioDuration computes playback duration from a standard PCM "fmt " chunk
+// and the size of the "data" chunk.
+func audioDuration(fmtData, data []byte) (time.Duration, error) {
+	if len(fmtData) < 16 {
+		return 0, fmt.Errorf("fmt chunk too short: %d bytes", len(fmtData))
+	}
+
+	channels := binary.LittleEndian.Uint16(fmtData[2:4])
+	sampleRate := binary.LittleEndian.Uint32(fmtData[4:8])
+	bitsPerSample := binary.LittleEndian.Uint16(fmtData[14:16])
+
+	if channels == 0 || sampleRate == 0 || bitsPerSample == 0 {
+		return 0, fmt.Errorf("invalid fmt chunk: channels=%d sampleRate=%d bitsPerSample=%d", channels, sampleRate, bitsPerSample)
+	}
+
+	bytesPerSecond := float64(sampleRate) * float64(channels) * float64(bitsPerSample) / 8
+
+	return time.Duration(float64(len(data)) / bytesPerSecond * float64(time.Second)), nil
+}
+
+// parseInfoChunk reads the public "LIST"/"INFO" subchunks written by
+// buildInfoChunk into r.Public.
+func parseInfoChunk(data []byte, r *Recording) error {
+	if len(data) < 4 || string(data[0:4]) != "INFO" {
+		return fmt.Errorf("LIST chunk is not type INFO")
+	}
+
+	fields := map[string]string{}
+
+	pos := 4
+	for pos+8 <= len(data) {
+		id := string(data[pos : pos+4])
+		size := binary.LittleEndian.Uint32(data[pos+4 : pos+8])
+		pos += 8
+
+		if pos+int(size) > len(data) {
+			return fmt.Errorf("INFO subchunk %s overruns chunk", id)
+		}
+		fields[id] = string(data[pos : pos+int(size)])
+		pos += int(size)
+
+		if size%2 == 1 {
+			pos++
+		}
+	}
+
+	r.Public.Product = fields["IPRD"]
+	if ts := fields["ICRD"]; ts != "" {
+		if t, err := time.Parse("2006-01-02T15:04:05Z", ts); err == nil {
+			r.Public.Timestamp = &t
+		}
+	}
+	r.Public.Channel = fields["INAM"]
+	r.Public.System = fields["IART"]
+	r.Public.Department = fields["ISBJ"]
+	r.Public.FavoriteListName = fields["ICMT"]
+	r.Public.TGIDFreq = fields["IKEY"]
+	if unitID := fields["ICOP"]; unitID != "" {
+		if v, err := strconv.ParseInt(unitID, 10, 64); err == nil {
+			r.Public.UnitID = v
+		}
+	}
+
+	return nil
+}
+
+// parsePrivateChunk reads the Uniden "unid" fields written by
+// buildPrivateChunk into r.Private.
+func parsePrivateChunk(data []byte, r *Recording) error {
+	fields := map[string]string{}
+
+	pos := 0
+	for pos+6 <= len(data) {
+		tag := string(data[pos : pos+4])
+		size := binary.LittleEndian.Uint16(data[pos+4 : pos+6])
+		pos += 6
+
+		if pos+int(size) > len(data) {
+			return fmt.Errorf("unid field %s overruns chunk", tag)
+		}
+		fields[tag] = string(data[pos : pos+int(size)])
+		pos += int(size)
+	}
+
+	r.Private.System.Type = fields["SYTY"]
+	r.Private.System.Name = fields["SYNM"]
+	if freq := fields["FREQ"]; freq != "" {
+		if v, err := strconv.ParseFloat(freq, 64); err == nil {
+			r.Private.Frequency = v
+		}
+	}
+	r.Private.FavoriteList.Name = fields["FAVN"]
+	r.Private.Department = fields["DEPT"]
+	r.Private.Channel = fields["CHAN"]
+	r.Private.Site.Name = fields["SITE"]
+	r.Private.TGID = fields["TGID"]
+	r.Private.UnitIDName = fields["UIDN"]
+	if unitID := fields["UNID"]; unitID != "" {
+		if v, err := strconv.ParseInt(unitID, 10, 64); err == nil {
+			r.Private.UnitID = v
+		}
+	}
+	if lat := fields["LAT0"]; lat != "" {
+		if v, err := strconv.ParseFloat(lat, 64); err == nil {
+			r.Private.Location.Latitude = v
+		}
+	}
+	if lon := fields["LON0"]; lon != "" {
+		if v, err := strconv.ParseFloat(lon, 64); err == nil {
+			r.Private.Location.Longitude = v
+		}
+	}
+
+	return nil
+}